@@ -1,24 +1,198 @@
 package main
 
 import (
-	"github.com/dylanmccormick/health-checker/assert"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"sync/atomic"
+	"time"
 )
 
-func ValidateUrls(urls []string) []string {
-	assert.Assert(len(urls) > 0, "Not enough URLs provided")
+// ValidateUrls validates each of urls with validateUrl and returns the
+// ones that pass. It returns an error joining every validation failure;
+// the returned slice may still be non-empty in that case. If none of
+// urls are valid, the returned slice is nil and the error is non-nil.
+func ValidateUrls(urls []string) ([]string, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("no URLs provided")
+	}
 
-	var returnUrls []string
+	var valid []string
+	var errs []error
 	for _, u := range urls {
-		if validateUrl(u) {
-			returnUrls = append(returnUrls, u)
+		if err := validateUrl(u); err != nil {
+			errs = append(errs, err)
+			continue
 		}
+		valid = append(valid, u)
+	}
+
+	if len(valid) == 0 {
+		return nil, errors.Join(append([]error{errors.New("no valid URLs provided")}, errs...)...)
+	}
+	return valid, errors.Join(errs...)
+}
+
+// validateUrl reports an error unless raw parses as an absolute
+// http(s) URL with a non-empty host.
+func validateUrl(raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("%q: %w", raw, err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf("%q: scheme must be http or https, got %q", raw, parsed.Scheme)
+	}
+	if parsed.Host == "" {
+		return fmt.Errorf("%q: missing host", raw)
+	}
+	return nil
+}
+
+// Checker performs a single health check against a target and returns a
+// non-nil error describing why the target is unhealthy. A nil error
+// means the target is healthy.
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerConfig is the on-disk shape of one entry in the config file's
+// `checkers` list. Settings holds the type-specific fields as raw JSON
+// so each checker factory only has to decode what it understands.
+type CheckerConfig struct {
+	Name             string          `json:"name"`
+	Type             string          `json:"type"`
+	IntervalSeconds  int             `json:"interval_seconds"`
+	TimeoutSeconds   int             `json:"timeout_seconds"`
+	Threshold        int             `json:"threshold"`
+	SuccessThreshold int             `json:"success_threshold"`
+	Settings         json.RawMessage `json:"settings"`
+}
+
+// CheckerFactory builds a Checker from its config entry.
+type CheckerFactory func(cfg CheckerConfig) (Checker, error)
+
+var checkerRegistry = map[string]CheckerFactory{}
+
+// RegisterChecker makes a checker type available under name for use as
+// the `type` field of a `checkers` entry in the config file.
+func RegisterChecker(name string, factory CheckerFactory) {
+	checkerRegistry[name] = factory
+}
+
+// NewChecker builds the Checker described by cfg using the registry
+// populated by RegisterChecker.
+func NewChecker(cfg CheckerConfig) (Checker, error) {
+	factory, ok := checkerRegistry[cfg.Type]
+	if !ok {
+		return nil, fmt.Errorf("checker %q: unknown type %q", cfg.Name, cfg.Type)
 	}
+	return factory(cfg)
+}
+
+func init() {
+	RegisterChecker("http", newHTTPChecker)
+}
+
+// HTTPChecker requests a URL and is unhealthy unless the response status
+// code is one of ExpectedCodes and, when BodyRegex is set, the response
+// body matches it. This is the checker that backs the original
+// HealthChecker.checkUrl behavior.
+type HTTPChecker struct {
+	Name          string
+	URL           string
+	Method        string
+	ExpectedCodes []int
+	BodyRegex     *regexp.Regexp
+	Client        *http.Client
 
-	assert.Assert(len(returnUrls) > 0, "No valid URLs provided")
-	return returnUrls
+	lastStatusCode atomic.Int32
 }
 
-func validateUrl(url string) bool {
+type httpCheckerSettings struct {
+	URL           string `json:"url"`
+	Method        string `json:"method"`
+	ExpectedCodes []int  `json:"expected_codes"`
+	BodyRegex     string `json:"body_regex"`
+}
+
+func newHTTPChecker(cfg CheckerConfig) (Checker, error) {
+	var s httpCheckerSettings
+	if err := json.Unmarshal(cfg.Settings, &s); err != nil {
+		return nil, fmt.Errorf("checker %q: %w", cfg.Name, err)
+	}
+	if s.URL == "" {
+		return nil, fmt.Errorf("checker %q: settings.url is required", cfg.Name)
+	}
+	if err := validateUrl(s.URL); err != nil {
+		return nil, fmt.Errorf("checker %q: %w", cfg.Name, err)
+	}
+	if s.Method == "" {
+		s.Method = http.MethodGet
+	}
+	if len(s.ExpectedCodes) == 0 {
+		s.ExpectedCodes = []int{http.StatusOK}
+	}
+
+	var re *regexp.Regexp
+	if s.BodyRegex != "" {
+		compiled, err := regexp.Compile(s.BodyRegex)
+		if err != nil {
+			return nil, fmt.Errorf("checker %q: invalid body_regex: %w", cfg.Name, err)
+		}
+		re = compiled
+	}
+
+	return &HTTPChecker{
+		Name:          cfg.Name,
+		URL:           s.URL,
+		Method:        s.Method,
+		ExpectedCodes: s.ExpectedCodes,
+		BodyRegex:     re,
+		Client: &http.Client{
+			Timeout: time.Duration(cfg.TimeoutSeconds) * time.Second,
+		},
+	}, nil
+}
+
+func (c *HTTPChecker) Check(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, c.Method, c.URL, nil)
+	if err != nil {
+		return fmt.Errorf("building request: %w", err)
+	}
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("requesting %s: %w", c.URL, err)
+	}
+	defer resp.Body.Close()
+	c.lastStatusCode.Store(int32(resp.StatusCode))
+
+	if !slices.Contains(c.ExpectedCodes, resp.StatusCode) {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	if c.BodyRegex != nil {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("reading response body: %w", err)
+		}
+		if !c.BodyRegex.Match(body) {
+			return fmt.Errorf("response body did not match %q", c.BodyRegex.String())
+		}
+	}
+
+	return nil
+}
 
-	return true
+// LastStatusCode returns the status code of the most recent request
+// that reached a response, or 0 if none has completed yet.
+func (c *HTTPChecker) LastStatusCode() int {
+	return int(c.lastStatusCode.Load())
 }
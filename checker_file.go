@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// FileChecker reports unhealthy when a file exists at Path. This is
+// useful as a manual "drain" switch: operators touch the file to pull a
+// target out of rotation and remove it to put the target back in.
+type FileChecker struct {
+	Name string
+	Path string
+}
+
+type fileCheckerSettings struct {
+	Path string `json:"path"`
+}
+
+func newFileChecker(cfg CheckerConfig) (Checker, error) {
+	var s fileCheckerSettings
+	if err := json.Unmarshal(cfg.Settings, &s); err != nil {
+		return nil, fmt.Errorf("checker %q: %w", cfg.Name, err)
+	}
+	if s.Path == "" {
+		return nil, fmt.Errorf("checker %q: settings.path is required", cfg.Name)
+	}
+
+	return &FileChecker{
+		Name: cfg.Name,
+		Path: s.Path,
+	}, nil
+}
+
+func (c *FileChecker) Check(ctx context.Context) error {
+	_, err := os.Stat(c.Path)
+	if err == nil {
+		return fmt.Errorf("drain file %s is present", c.Path)
+	}
+	if !os.IsNotExist(err) {
+		return fmt.Errorf("statting %s: %w", c.Path, err)
+	}
+	return nil
+}
+
+func init() {
+	RegisterChecker("file", newFileChecker)
+}
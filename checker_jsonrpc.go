@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// JSONRPCChecker POSTs a JSON-RPC request and is unhealthy unless the
+// field named by ResultField in the response's result object equals
+// false. ResultField defaults to "result", meaning the whole result
+// value itself is compared to false, which matches the reth/geth
+// convention of endpoints like `eth_syncing`: they return `false` when
+// idle and a status object otherwise. Setting ResultField to something
+// else lets a checker target one field of a result object instead, e.g.
+// a `{"syncing": false, ...}` response.
+type JSONRPCChecker struct {
+	Name        string
+	URL         string
+	Method      string
+	Params      []any
+	ResultField string
+	Client      *http.Client
+}
+
+type jsonrpcCheckerSettings struct {
+	URL         string `json:"url"`
+	Method      string `json:"method"`
+	Params      []any  `json:"params"`
+	ResultField string `json:"result_field"`
+}
+
+type jsonrpcRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int    `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+type jsonrpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func newJSONRPCChecker(cfg CheckerConfig) (Checker, error) {
+	var s jsonrpcCheckerSettings
+	if err := json.Unmarshal(cfg.Settings, &s); err != nil {
+		return nil, fmt.Errorf("checker %q: %w", cfg.Name, err)
+	}
+	if s.URL == "" {
+		return nil, fmt.Errorf("checker %q: settings.url is required", cfg.Name)
+	}
+	if s.Method == "" {
+		return nil, fmt.Errorf("checker %q: settings.method is required", cfg.Name)
+	}
+	if s.ResultField == "" {
+		s.ResultField = "result"
+	}
+
+	return &JSONRPCChecker{
+		Name:        cfg.Name,
+		URL:         s.URL,
+		Method:      s.Method,
+		Params:      s.Params,
+		ResultField: s.ResultField,
+		Client: &http.Client{
+			Timeout: time.Duration(cfg.TimeoutSeconds) * time.Second,
+		},
+	}, nil
+}
+
+func (c *JSONRPCChecker) Check(ctx context.Context) error {
+	body, err := json.Marshal(jsonrpcRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  c.Method,
+		Params:  c.Params,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("building request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("requesting %s: %w", c.URL, err)
+	}
+	defer resp.Body.Close()
+
+	var rpcResp jsonrpcResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	if rpcResp.Error != nil {
+		return fmt.Errorf("%s returned error: %s", c.Method, rpcResp.Error.Message)
+	}
+
+	field, err := c.resultField(rpcResp.Result)
+	if err != nil {
+		return err
+	}
+	if field != false {
+		return fmt.Errorf("%s: expected %s to be false, got %v", c.Method, c.ResultField, field)
+	}
+
+	return nil
+}
+
+// resultField returns the value named by c.ResultField out of result.
+// When ResultField is "result" (the default), that's the whole result
+// value itself; otherwise result is expected to decode as a JSON object
+// and the named key is looked up within it.
+func (c *JSONRPCChecker) resultField(result json.RawMessage) (any, error) {
+	var decoded any
+	if err := json.Unmarshal(result, &decoded); err != nil {
+		return nil, fmt.Errorf("decoding result: %w", err)
+	}
+	if c.ResultField == "result" {
+		return decoded, nil
+	}
+
+	obj, ok := decoded.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s: result is not an object, can't read field %q", c.Method, c.ResultField)
+	}
+	value, ok := obj[c.ResultField]
+	if !ok {
+		return nil, fmt.Errorf("%s: result has no field %q", c.Method, c.ResultField)
+	}
+	return value, nil
+}
+
+func init() {
+	RegisterChecker("jsonrpc", newJSONRPCChecker)
+}
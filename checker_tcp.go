@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+)
+
+// TCPChecker is unhealthy unless it can open a TCP connection to
+// Address within Timeout.
+type TCPChecker struct {
+	Name    string
+	Address string
+	Timeout time.Duration
+}
+
+type tcpCheckerSettings struct {
+	Address string `json:"address"`
+}
+
+func newTCPChecker(cfg CheckerConfig) (Checker, error) {
+	var s tcpCheckerSettings
+	if err := json.Unmarshal(cfg.Settings, &s); err != nil {
+		return nil, fmt.Errorf("checker %q: %w", cfg.Name, err)
+	}
+	if s.Address == "" {
+		return nil, fmt.Errorf("checker %q: settings.address is required", cfg.Name)
+	}
+
+	return &TCPChecker{
+		Name:    cfg.Name,
+		Address: s.Address,
+		Timeout: time.Duration(cfg.TimeoutSeconds) * time.Second,
+	}, nil
+}
+
+func (c *TCPChecker) Check(ctx context.Context) error {
+	dialer := net.Dialer{Timeout: c.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", c.Address)
+	if err != nil {
+		return fmt.Errorf("dialing %s: %w", c.Address, err)
+	}
+	return conn.Close()
+}
+
+func init() {
+	RegisterChecker("tcp", newTCPChecker)
+}
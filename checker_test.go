@@ -0,0 +1,44 @@
+package main
+
+import "testing"
+
+func TestValidateUrl(t *testing.T) {
+	cases := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{"valid https", "https://example.com/health", false},
+		{"valid http", "http://localhost:8080", false},
+		{"missing scheme", "example.com", true},
+		{"unsupported scheme", "ftp://example.com", true},
+		{"missing host", "https:///path", true},
+		{"unparseable", "https://example.com/%zz", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateUrl(tc.url)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("validateUrl(%q) error = %v, wantErr %v", tc.url, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateUrls(t *testing.T) {
+	valid, err := ValidateUrls([]string{"https://good.example.com", "https://example.com/%zz"})
+	if err == nil {
+		t.Fatal("ValidateUrls() error = nil, want non-nil for a mix of valid and invalid URLs")
+	}
+	if len(valid) != 1 || valid[0] != "https://good.example.com" {
+		t.Errorf("ValidateUrls() valid = %v, want only the valid URL", valid)
+	}
+
+	if _, err := ValidateUrls([]string{"https://example.com/%zz"}); err == nil {
+		t.Error("ValidateUrls() error = nil, want non-nil when no URLs are valid")
+	}
+
+	if _, err := ValidateUrls(nil); err == nil {
+		t.Error("ValidateUrls(nil) error = nil, want non-nil")
+	}
+}
@@ -5,15 +5,27 @@ import (
 	"fmt"
 	"net/http"
 	"os"
-
-	"github.com/dylanmccormick/health-checker/assert"
 )
 
 type Config struct {
-	IntervalSeconds int      `json:"check_interval_seconds"`
-	TimeoutSeconds  int      `json:"timeout_seconds"`
-	Urls            []string `json:"Urls"`
-	httpClient      *http.Client
+	IntervalSeconds int             `json:"check_interval_seconds"`
+	TimeoutSeconds  int             `json:"timeout_seconds"`
+	Checkers        []CheckerConfig `json:"checkers"`
+	// ListenAddr is the address the embedded /metrics and /healthz
+	// server listens on, e.g. ":9090". The server is disabled if empty.
+	ListenAddr string `json:"listen_addr"`
+	// JitterFraction applies up to ±JitterFraction of random jitter to
+	// every checker's interval, e.g. 0.1 for ±10%. 0 disables jitter.
+	JitterFraction float64 `json:"jitter_fraction"`
+	// WindowSize is the number of recent (timestamp, duration, success)
+	// samples kept per checker for uptime/percentile reporting. Defaults
+	// to 100 if unset.
+	WindowSize int `json:"window_size"`
+	// SocketPath is where the status Unix socket listens, consumed by
+	// the `health-checker status` CLI subcommand. Defaults to
+	// defaultSocketPath if unset.
+	SocketPath string `json:"socket_path"`
+	httpClient *http.Client
 }
 
 func GetConfig() (Config, error) {
@@ -37,26 +49,39 @@ func GetConfig() (Config, error) {
 
 func validateConfig(c Config) (Config, error) {
 	// At some point we should have default values which are set if something doesn't exist
-	assert.Assert(c.IntervalSeconds > 0, "Interval Seconds Must Be Positive")
-	assert.Assert(c.TimeoutSeconds > 0, "Timeout Seconds Must Be Positive")
-	c.Urls = ValidateUrls(c.Urls)
-	return c, nil
-}
-
-func ValidateUrls(urls []string) []string {
-	assert.Assert(len(urls) > 0, "No URLs provided")
+	if c.IntervalSeconds <= 0 {
+		return Config{}, fmt.Errorf("check_interval_seconds must be positive")
+	}
+	if c.TimeoutSeconds <= 0 {
+		return Config{}, fmt.Errorf("timeout_seconds must be positive")
+	}
+	if len(c.Checkers) == 0 {
+		return Config{}, fmt.Errorf("no checkers configured")
+	}
 
-	var returnUrls []string
-	for _, u := range urls {
-		if validateUrl(u) {
-			returnUrls = append(returnUrls, u)
+	seenNames := make(map[string]struct{}, len(c.Checkers))
+	for _, checker := range c.Checkers {
+		if _, dup := seenNames[checker.Name]; dup {
+			return Config{}, fmt.Errorf("duplicate checker name %q", checker.Name)
 		}
+		seenNames[checker.Name] = struct{}{}
 	}
 
-	assert.Assert(len(returnUrls) > 0, "No valid URLs provided")
-	return returnUrls
-}
+	var httpUrls []string
+	for _, checker := range c.Checkers {
+		if checker.Type != "http" {
+			continue
+		}
+		var s httpCheckerSettings
+		if err := json.Unmarshal(checker.Settings, &s); err == nil && s.URL != "" {
+			httpUrls = append(httpUrls, s.URL)
+		}
+	}
+	if len(httpUrls) > 0 {
+		if _, err := ValidateUrls(httpUrls); err != nil {
+			return Config{}, fmt.Errorf("validating checker URLs: %w", err)
+		}
+	}
 
-func validateUrl(url string) bool {
-	return true
+	return c, nil
 }
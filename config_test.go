@@ -0,0 +1,33 @@
+package main
+
+import "testing"
+
+func TestValidateConfigRejectsDuplicateCheckerNames(t *testing.T) {
+	c := Config{
+		IntervalSeconds: 1,
+		TimeoutSeconds:  1,
+		Checkers: []CheckerConfig{
+			{Name: "a", Type: "file", Settings: []byte(`{"path":"/tmp/a"}`)},
+			{Name: "a", Type: "file", Settings: []byte(`{"path":"/tmp/b"}`)},
+		},
+	}
+
+	if _, err := validateConfig(c); err == nil {
+		t.Error("validateConfig() error = nil, want non-nil for duplicate checker names")
+	}
+}
+
+func TestValidateConfigAcceptsUniqueCheckerNames(t *testing.T) {
+	c := Config{
+		IntervalSeconds: 1,
+		TimeoutSeconds:  1,
+		Checkers: []CheckerConfig{
+			{Name: "a", Type: "file", Settings: []byte(`{"path":"/tmp/a"}`)},
+			{Name: "b", Type: "file", Settings: []byte(`{"path":"/tmp/b"}`)},
+		},
+	}
+
+	if _, err := validateConfig(c); err != nil {
+		t.Errorf("validateConfig() error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// ConfigWatcher reloads the config file on SIGHUP and applies the
+// result to a running HealthChecker: checkers that disappeared are
+// cancelled, checkers that are new are started, and checkers that are
+// unchanged keep their existing metrics and scheduler untouched. A
+// fsnotify-backed watcher could call Reload on file changes too; only
+// SIGHUP is wired up today.
+type ConfigWatcher struct {
+	checker    *HealthChecker
+	loadConfig func() (Config, error)
+}
+
+// NewConfigWatcher builds a ConfigWatcher that applies reloads to
+// checker, loading the new config with loadConfig (normally GetConfig).
+func NewConfigWatcher(checker *HealthChecker, loadConfig func() (Config, error)) *ConfigWatcher {
+	return &ConfigWatcher{checker: checker, loadConfig: loadConfig}
+}
+
+// Watch blocks until ctx is done, calling Reload on every SIGHUP.
+func (w *ConfigWatcher) Watch(ctx context.Context) {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGHUP)
+	defer signal.Stop(sigChan)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sigChan:
+			slog.Info("received SIGHUP, reloading config")
+			if err := w.Reload(); err != nil {
+				slog.Error("config reload failed, keeping running config", "error", err)
+			}
+		}
+	}
+}
+
+// Reload loads the config and applies it to the watcher's checker. A
+// failed load or validation leaves the running config untouched.
+func (w *ConfigWatcher) Reload() error {
+	conf, err := w.loadConfig()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	w.checker.ApplyConfig(conf)
+	return nil
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// serveHTTP starts the embedded HTTP server on h.config.ListenAddr and
+// blocks until ctx is done, at which point it shuts the server down
+// gracefully. It is a no-op if ListenAddr is empty.
+func (h *HealthChecker) serveHTTP(ctx context.Context) {
+	listenAddr := h.getConfig().ListenAddr
+	if listenAddr == "" {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", h.handleMetrics)
+	mux.HandleFunc("/healthz", h.handleHealthz)
+	mux.HandleFunc("/status", h.handleStatus)
+	srv := &http.Server{Addr: listenAddr, Handler: mux}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			slog.Error("http server shutdown failed", "error", err)
+		}
+	}()
+
+	slog.Info("starting http server", "addr", listenAddr)
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		slog.Error("http server stopped unexpectedly", "error", err)
+	}
+}
+
+// handleHealthz serves a JSON summary of every checker's health.
+// Without query params it returns { "name": "OK" | "<error>" } and HTTP
+// 200 if every checker is healthy, 503 otherwise. With ?verbose=1 it
+// instead returns per-checker metadata (last check time, consecutive
+// failures, threshold).
+func (h *HealthChecker) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	snapshots := h.registry.All()
+	verbose := r.URL.Query().Get("verbose") == "1"
+
+	allHealthy := true
+	body := make(map[string]any, len(snapshots))
+	for name, snap := range snapshots {
+		if !snap.Healthy {
+			allHealthy = false
+		}
+		if verbose {
+			body[name] = verboseHealthzEntry{
+				Healthy:             snap.Healthy,
+				LastCheckTime:       snap.LastCheckTime,
+				ConsecutiveFailures: snap.ConsecutiveFails,
+				Threshold:           snap.Threshold,
+			}
+			continue
+		}
+		if snap.Healthy {
+			body[name] = "OK"
+		} else if snap.LastUnhealthyError != nil {
+			body[name] = snap.LastUnhealthyError.Error()
+		} else {
+			body[name] = "unhealthy"
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if allHealthy {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		slog.Error("failed to encode /healthz response", "error", err)
+	}
+}
+
+type verboseHealthzEntry struct {
+	Healthy             bool      `json:"healthy"`
+	LastCheckTime       time.Time `json:"last_check_time"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	Threshold           int       `json:"threshold"`
+}
+
+// handleMetrics serves checker metrics in Prometheus text exposition
+// format.
+func (h *HealthChecker) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	snapshots := h.registry.All()
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	writeMetricHeader(w, "health_checker_checks_total", "counter", "Total number of checks run, per checker.")
+	for name, snap := range snapshots {
+		fmt.Fprintf(w, "health_checker_checks_total{checker=%q} %d\n", name, snap.TotalChecks)
+	}
+
+	writeMetricHeader(w, "health_checker_checks_successful_total", "counter", "Total number of successful checks, per checker.")
+	for name, snap := range snapshots {
+		fmt.Fprintf(w, "health_checker_checks_successful_total{checker=%q} %d\n", name, snap.SuccessfulChecks)
+	}
+
+	writeMetricHeader(w, "health_checker_healthy", "gauge", "1 if the checker is currently healthy, 0 otherwise.")
+	for name, snap := range snapshots {
+		fmt.Fprintf(w, "health_checker_healthy{checker=%q} %s\n", name, boolToGaugeValue(snap.Healthy))
+	}
+
+	writeMetricHeader(w, "health_checker_response_time_seconds", "histogram", "Check response times, in seconds.")
+	for name, snap := range snapshots {
+		writeResponseTimeHistogram(w, name, snap)
+	}
+}
+
+func writeMetricHeader(w io.Writer, name, metricType, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, metricType)
+}
+
+func boolToGaugeValue(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+func writeResponseTimeHistogram(w io.Writer, name string, snap CheckerSnapshot) {
+	for i, le := range responseTimeBuckets {
+		fmt.Fprintf(w, "health_checker_response_time_seconds_bucket{checker=%q,le=%q} %d\n",
+			name, strconv.FormatFloat(le, 'g', -1, 64), snap.ResponseTimeBuckets[i])
+	}
+	fmt.Fprintf(w, "health_checker_response_time_seconds_bucket{checker=%q,le=\"+Inf\"} %d\n", name, snap.TotalChecks)
+	fmt.Fprintf(w, "health_checker_response_time_seconds_sum{checker=%q} %g\n", name, snap.ResponseTimeSum.Seconds())
+	fmt.Fprintf(w, "health_checker_response_time_seconds_count{checker=%q} %d\n", name, snap.TotalChecks)
+}
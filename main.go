@@ -2,11 +2,12 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log/slog"
-	"net/http"
 	"os"
 	"os/signal"
+	"reflect"
 	"sync"
 	"syscall"
 	"time"
@@ -16,19 +17,78 @@ import (
 
 type HealthChecker struct {
 	// this will hold metrics and stuff later. Good place to store things for uptime and whatever else we may want
-	httpClient *http.Client
-	config     Config
-	metrics    map[string]*URLMetrics
+	// mu guards config, checkers, checkerConfigs, metrics, cancelFns, and
+	// doneChs, all of which ConfigWatcher reloads can mutate while
+	// schedulers are running.
+	mu             sync.RWMutex
+	config         Config
+	checkers       map[string]Checker
+	checkerConfigs map[string]CheckerConfig
+	metrics        map[string]*URLMetrics
+	cancelFns      map[string]context.CancelFunc
+	// doneChs holds a channel per running checker that's closed once its
+	// monitorChecker goroutine has returned, i.e. once its scheduler's
+	// in-flight check (if any) has finished. stopChecker waits on this
+	// before clearing metrics/registry, so a check that's already
+	// running when a checker is removed can't look up a metrics entry
+	// that was deleted out from under it.
+	doneChs       map[string]chan struct{}
+	registry      *Registry
+	onStateChange func(target string, healthy bool, err error)
+
+	wg     *sync.WaitGroup
+	runCtx context.Context
 }
 
 type URLMetrics struct {
-	TotalChecks       int
-	SuccessfulChecks  int
-	TotalResponseTime time.Duration
-	Mutex             *sync.RWMutex
+	TotalChecks        int
+	SuccessfulChecks   int
+	TotalResponseTime  time.Duration
+	ConsecutiveFails   int
+	ConsecutiveSuccess int
+	Healthy            bool
+	LastError          error
+	// LastUnhealthyError is the error that caused Healthy's most recent
+	// transition to false. Unlike LastError, which reflects the most
+	// recent check of any outcome, this stays populated across the
+	// successful checks a SuccessThreshold > 1 requires before Healthy
+	// flips back to true, so callers that only care "why is this
+	// currently unhealthy" don't have to special-case a nil LastError.
+	LastUnhealthyError  error
+	LastCheckTime       time.Time
+	ResponseTimeBuckets []int
+	Window              *ringBuffer
+	Mutex               *sync.RWMutex
+}
+
+// defaultThreshold is used whenever a checker doesn't configure a
+// positive Threshold/SuccessThreshold, so a single check still flips
+// state the way the old unconditional logging did.
+const defaultThreshold = 1
+
+// OnStateChange registers fn to be called whenever a checker flips
+// between healthy and unhealthy, after its configured Threshold or
+// SuccessThreshold consecutive outcomes. err is the error from the
+// check that triggered the transition, and is nil when healthy is true.
+// fn is called synchronously from the monitoring goroutine, so it
+// should not block.
+func (h *HealthChecker) OnStateChange(fn func(target string, healthy bool, err error)) {
+	h.onStateChange = fn
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "status" {
+		fs := flag.NewFlagSet("status", flag.ExitOnError)
+		socketPath := fs.String("socket", defaultSocketPath, "path to the running instance's status Unix socket")
+		fs.Parse(os.Args[2:])
+		runStatusCommand(*socketPath)
+		return
+	}
+
+	runDaemon()
+}
+
+func runDaemon() {
 	w := os.Stderr
 	slog.SetDefault(slog.New(
 		tint.NewHandler(w, &tint.Options{
@@ -50,8 +110,6 @@ func main() {
 		slog.Error("Error getting config", "error", err)
 		os.Exit(1)
 	}
-	validUrls := ValidateUrls(conf.Urls)
-	conf.Urls = validUrls
 
 	h := NewHealthChecker(conf)
 	h.Run(ctx)
@@ -60,121 +118,370 @@ func main() {
 }
 
 func NewHealthChecker(c Config) *HealthChecker {
+	checkers := make(map[string]Checker, len(c.Checkers))
+	checkerConfigs := make(map[string]CheckerConfig, len(c.Checkers))
+	for _, cc := range c.Checkers {
+		checker, err := NewChecker(cc)
+		if err != nil {
+			slog.Error("failed to build checker, skipping", "name", cc.Name, "error", err)
+			continue
+		}
+		checkers[cc.Name] = checker
+		checkerConfigs[cc.Name] = cc
+	}
+
 	return &HealthChecker{
-		httpClient: &http.Client{
-			Timeout: time.Duration(c.TimeoutSeconds) * time.Second,
-		},
-		config:  c,
-		metrics: make(map[string]*URLMetrics),
+		config:         c,
+		checkers:       checkers,
+		checkerConfigs: checkerConfigs,
+		metrics:        make(map[string]*URLMetrics),
+		cancelFns:      make(map[string]context.CancelFunc),
+		doneChs:        make(map[string]chan struct{}),
+		registry:       NewRegistry(),
 	}
 }
 
 func (h *HealthChecker) Run(ctx context.Context) {
-	var wg sync.WaitGroup
-	wg.Go(func() {
+	h.runCtx = ctx
+	h.wg = &sync.WaitGroup{}
+
+	h.wg.Go(func() {
 		h.logMetrics(ctx)
 	})
-	for _, url := range h.config.Urls {
-		wg.Add(1)
-		go func(url string) {
-			defer wg.Done()
-			h.createUrlEntry(ctx, url)
-			h.monitorUrl(ctx, url)
-		}(url)
-	}
-	wg.Wait()
+	h.wg.Go(func() {
+		h.serveHTTP(ctx)
+	})
+	h.wg.Go(func() {
+		h.serveSocket(ctx)
+	})
+
+	h.mu.RLock()
+	initial := make(map[string]Checker, len(h.checkers))
+	for name, checker := range h.checkers {
+		initial[name] = checker
+	}
+	h.mu.RUnlock()
+	for name, checker := range initial {
+		h.startChecker(ctx, name, checker)
+	}
+
+	watcher := NewConfigWatcher(h, GetConfig)
+	h.wg.Go(func() {
+		watcher.Watch(ctx)
+	})
+
+	h.wg.Wait()
 	slog.Info("All healthchecks stopped")
 }
 
-func (h *HealthChecker) createUrlEntry(ctx context.Context, url string) {
-	h.metrics[url] = &URLMetrics{
+// startChecker launches name's scheduler under a context derived from
+// parent, so stopChecker can cancel it independently of the others when
+// the config is reloaded.
+func (h *HealthChecker) startChecker(parent context.Context, name string, checker Checker) {
+	checkerCtx, cancel := context.WithCancel(parent)
+	done := make(chan struct{})
+
+	h.mu.Lock()
+	h.cancelFns[name] = cancel
+	h.doneChs[name] = done
+	h.mu.Unlock()
+
+	h.createCheckerEntry(name)
+	h.wg.Add(1)
+	go func() {
+		defer close(done)
+		h.monitorChecker(checkerCtx, name, checker, h.wg)
+	}()
+}
+
+// stopChecker cancels name's scheduler and waits for its monitorChecker
+// goroutine to fully stop - including any check that was already in
+// flight - before removing it, its metrics, and its published registry
+// snapshot from the running set. Waiting first means a check that's
+// mid-flight when a reload removes its checker can't be left looking up
+// a metrics entry that's already gone.
+func (h *HealthChecker) stopChecker(name string) {
+	h.mu.Lock()
+	cancel, ok := h.cancelFns[name]
+	done := h.doneChs[name]
+	delete(h.cancelFns, name)
+	delete(h.doneChs, name)
+	delete(h.checkers, name)
+	delete(h.checkerConfigs, name)
+	h.mu.Unlock()
+
+	if ok {
+		cancel()
+	}
+	if done != nil {
+		<-done
+	}
+
+	h.mu.Lock()
+	delete(h.metrics, name)
+	h.mu.Unlock()
+	h.registry.Delete(name)
+}
+
+// ApplyConfig diffs newConfig's checkers against the running set by
+// name: new checkers are started, removed checkers are stopped, and
+// checkers present in both are restarted with fresh metrics whenever
+// their CheckerConfig changed (e.g. a new URL, threshold, or interval).
+// A checker whose config is byte-for-byte unchanged is left running
+// untouched, keeping its metrics and registry snapshot.
+func (h *HealthChecker) ApplyConfig(newConfig Config) {
+	h.mu.RLock()
+	oldConfigs := make(map[string]CheckerConfig, len(h.checkerConfigs))
+	for name, cc := range h.checkerConfigs {
+		oldConfigs[name] = cc
+	}
+	h.mu.RUnlock()
+
+	newNames := make(map[string]struct{}, len(newConfig.Checkers))
+	for _, cc := range newConfig.Checkers {
+		newNames[cc.Name] = struct{}{}
+	}
+
+	for name := range oldConfigs {
+		if _, ok := newNames[name]; ok {
+			continue
+		}
+		h.stopChecker(name)
+		slog.Info("checker removed by config reload", "checker", name)
+	}
+
+	for _, cc := range newConfig.Checkers {
+		old, existed := oldConfigs[cc.Name]
+		if existed && reflect.DeepEqual(old, cc) {
+			slog.Debug("checker unchanged by config reload", "checker", cc.Name)
+			continue
+		}
+
+		checker, err := NewChecker(cc)
+		if err != nil {
+			slog.Error("failed to build checker from reloaded config, skipping", "name", cc.Name, "error", err)
+			continue
+		}
+
+		if existed {
+			h.stopChecker(cc.Name)
+		}
+
+		h.mu.Lock()
+		h.checkers[cc.Name] = checker
+		h.checkerConfigs[cc.Name] = cc
+		h.mu.Unlock()
+		h.startChecker(h.runCtx, cc.Name, checker)
+
+		if existed {
+			slog.Info("checker restarted by config reload", "checker", cc.Name)
+		} else {
+			slog.Info("checker added by config reload", "checker", cc.Name)
+		}
+	}
+
+	h.mu.Lock()
+	h.config = newConfig
+	h.mu.Unlock()
+	slog.Info("config reloaded", "checkers", len(newNames))
+}
+
+// defaultWindowSize is used when Config.WindowSize isn't set.
+const defaultWindowSize = 100
+
+func (h *HealthChecker) createCheckerEntry(name string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	windowSize := h.config.WindowSize
+	if windowSize <= 0 {
+		windowSize = defaultWindowSize
+	}
+	h.metrics[name] = &URLMetrics{
 		TotalChecks:       0,
 		SuccessfulChecks:  0,
 		TotalResponseTime: time.Duration(0),
-		Mutex:             new(sync.RWMutex),
+		// Assume healthy until Threshold consecutive failures prove
+		// otherwise, so a brand-new checker doesn't fire a spurious
+		// unhealthy transition on its first check.
+		Healthy:             true,
+		ResponseTimeBuckets: make([]int, len(responseTimeBuckets)),
+		Window:              newRingBuffer(windowSize),
+		Mutex:               new(sync.RWMutex),
 	}
 }
 
+// getConfig returns a copy of the current Config under a read lock, so
+// readers are safe against a concurrent ApplyConfig reload.
+func (h *HealthChecker) getConfig() Config {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.config
+}
+
+// getCheckerConfig returns the current CheckerConfig for name.
+func (h *HealthChecker) getCheckerConfig(name string) CheckerConfig {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.checkerConfigs[name]
+}
+
+// getMetricsEntry returns the URLMetrics for name.
+func (h *HealthChecker) getMetricsEntry(name string) *URLMetrics {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.metrics[name]
+}
+
 func (h *HealthChecker) logMetrics(ctx context.Context) {
-	ticker := time.NewTicker(time.Duration(h.config.IntervalSeconds) * time.Second)
+	ticker := time.NewTicker(time.Duration(h.getConfig().IntervalSeconds) * time.Second)
 	for {
 		select {
 		case <-ctx.Done():
 			slog.Info("stopping health check metrics", "reason", ctx.Err())
 			return
 		case <-ticker.C:
-			for _, u := range h.config.Urls {
-				m := h.metrics[u]
-				m.Mutex.RLock()
-				if m.TotalChecks == 0 {
-					m.Mutex.RUnlock()
+			for name, snap := range h.registry.All() {
+				if snap.TotalChecks == 0 {
 					continue
 				}
 				slog.Info(
 					"metrics",
-					"URL", u,
-					"TotalChecks", m.TotalChecks,
-					"SuccessfulChecks", m.SuccessfulChecks,
-					"AvgResponseTime", fmt.Sprintf("%dms", int(m.TotalResponseTime.Milliseconds())/m.TotalChecks),
+					"checker", name,
+					"TotalChecks", snap.TotalChecks,
+					"SuccessfulChecks", snap.SuccessfulChecks,
+					"AvgResponseTime", fmt.Sprintf("%dms", snap.ResponseTimeSum.Milliseconds()/int64(snap.TotalChecks)),
 				)
-				m.Mutex.RUnlock()
 			}
 		}
 	}
 }
 
-func (h *HealthChecker) monitorUrl(ctx context.Context, url string) {
-	ticker := time.NewTicker(time.Duration(h.config.IntervalSeconds) * time.Second)
-	for {
-		select {
-		case <-ctx.Done():
-			slog.Info("stopping health check for URL", "url", url, "reason", ctx.Err())
-			return
-		case <-ticker.C:
-			h.checkUrl(ctx, url)
-		}
+func (h *HealthChecker) monitorChecker(ctx context.Context, name string, checker Checker, wg *sync.WaitGroup) {
+	cfg := h.getCheckerConfig(name)
+	global := h.getConfig()
+	scheduler := &AsyncScheduler{
+		Name:           name,
+		Interval:       checkInterval(cfg, global),
+		Timeout:        checkTimeout(cfg, global),
+		JitterFraction: global.JitterFraction,
+		Task: func(taskCtx context.Context) {
+			h.runCheck(taskCtx, name, checker)
+		},
+	}
+	scheduler.Run(ctx, wg)
+	slog.Info("stopping health check", "checker", name, "reason", ctx.Err())
+}
+
+// checkInterval returns cfg's own IntervalSeconds, falling back to the
+// global default when the checker doesn't configure one, the same way
+// checkTimeout falls back for Timeout.
+func checkInterval(cfg CheckerConfig, global Config) time.Duration {
+	if cfg.IntervalSeconds > 0 {
+		return time.Duration(cfg.IntervalSeconds) * time.Second
 	}
+	return time.Duration(global.IntervalSeconds) * time.Second
 }
 
-func (h *HealthChecker) checkUrl(ctx context.Context, url string) {
+// checkTimeout returns cfg's own TimeoutSeconds, falling back to the
+// global default when the checker doesn't configure one.
+func checkTimeout(cfg CheckerConfig, global Config) time.Duration {
+	if cfg.TimeoutSeconds > 0 {
+		return time.Duration(cfg.TimeoutSeconds) * time.Second
+	}
+	return time.Duration(global.TimeoutSeconds) * time.Second
+}
+
+func (h *HealthChecker) runCheck(ctx context.Context, name string, checker Checker) {
 	start := time.Now()
-	m := h.metrics[url]
+	cfg := h.getCheckerConfig(name)
+	m := h.getMetricsEntry(name)
+
+	err := checker.Check(ctx)
+	responseTime := time.Since(start)
+
 	m.Mutex.Lock()
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		slog.Error("failed to create http request", "url", url, "error", err)
-		return
-	}
 	m.TotalChecks += 1
-	resp, err := h.httpClient.Do(req)
+	m.TotalResponseTime += responseTime
+	m.LastError = err
+	m.LastCheckTime = start
+	for i, le := range responseTimeBuckets {
+		if responseTime.Seconds() <= le {
+			m.ResponseTimeBuckets[i] += 1
+		}
+	}
+	m.Window.Add(sample{Timestamp: start, Duration: responseTime, Success: err == nil})
+
+	var transitioned, nowHealthy bool
+
 	if err != nil {
-		responseTime := time.Since(start)
-		slog.Error("",
-			"url", url,
-			"status", "NONE",
-			"healthy", false,
+		m.ConsecutiveFails += 1
+		m.ConsecutiveSuccess = 0
+		slog.Warn("check failing",
+			"checker", name,
+			"error", err,
+			"consecutive_failures", m.ConsecutiveFails,
 			"response_time", fmt.Sprintf("%dms", responseTime.Milliseconds()))
-		m.Mutex.Unlock()
+		if m.Healthy && m.ConsecutiveFails >= threshold(cfg.Threshold) {
+			m.Healthy = false
+			m.LastUnhealthyError = err
+			transitioned, nowHealthy = true, false
+		}
+	} else {
+		m.SuccessfulChecks += 1
+		m.ConsecutiveSuccess += 1
+		m.ConsecutiveFails = 0
+		if !m.Healthy && m.ConsecutiveSuccess >= threshold(cfg.SuccessThreshold) {
+			m.Healthy = true
+			m.LastUnhealthyError = nil
+			transitioned, nowHealthy = true, true
+		}
+	}
+	h.publishSnapshot(name, cfg, m)
+	m.Mutex.Unlock()
+
+	if !transitioned {
 		return
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		m.SuccessfulChecks += 1
-		responseTime := time.Since(start)
-		m.TotalResponseTime += responseTime
-		slog.Info("",
-			"url", url,
-			"status", resp.StatusCode,
-			"healthy", true,
-			"response_time", fmt.Sprintf("%dms", responseTime.Milliseconds()))
+
+	if nowHealthy {
+		slog.Info("checker state transition", "checker", name, "healthy", true)
 	} else {
-		responseTime := time.Since(start)
-		m.TotalResponseTime += responseTime
-		slog.Error("",
-			"url", url,
-			"status", resp.StatusCode,
-			"healthy", false,
-			"response_time", fmt.Sprintf("%dms", responseTime.Milliseconds()))
+		slog.Warn("checker state transition", "checker", name, "healthy", false, "error", err)
 	}
-	m.Mutex.Unlock()
+	if h.onStateChange != nil {
+		h.onStateChange(name, nowHealthy, err)
+	}
+}
+
+// publishSnapshot copies m's fields into the registry under name. The
+// caller must hold m.Mutex.
+func (h *HealthChecker) publishSnapshot(name string, cfg CheckerConfig, m *URLMetrics) {
+	buckets := make([]int, len(m.ResponseTimeBuckets))
+	copy(buckets, m.ResponseTimeBuckets)
+
+	h.registry.Set(name, CheckerSnapshot{
+		Name:                name,
+		TotalChecks:         m.TotalChecks,
+		SuccessfulChecks:    m.SuccessfulChecks,
+		ConsecutiveFails:    m.ConsecutiveFails,
+		ConsecutiveSuccess:  m.ConsecutiveSuccess,
+		Healthy:             m.Healthy,
+		LastError:           m.LastError,
+		LastUnhealthyError:  m.LastUnhealthyError,
+		LastCheckTime:       m.LastCheckTime,
+		Threshold:           threshold(cfg.Threshold),
+		SuccessThreshold:    threshold(cfg.SuccessThreshold),
+		ResponseTimeSum:     m.TotalResponseTime,
+		ResponseTimeBuckets: buckets,
+	})
+}
+
+// threshold returns n, or defaultThreshold if n isn't positive, so an
+// unconfigured Threshold/SuccessThreshold still flips state on the
+// first contrary outcome.
+func threshold(n int) int {
+	if n <= 0 {
+		return defaultThreshold
+	}
+	return n
 }
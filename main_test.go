@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+func TestThreshold(t *testing.T) {
+	cases := []struct {
+		name string
+		n    int
+		want int
+	}{
+		{"positive passes through", 5, 5},
+		{"zero defaults", 0, defaultThreshold},
+		{"negative defaults", -1, defaultThreshold},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := threshold(tc.n); got != tc.want {
+				t.Errorf("threshold(%d) = %d, want %d", tc.n, got, tc.want)
+			}
+		})
+	}
+}
+
+// fakeChecker is a Checker registered under the "fake" type for tests
+// that exercise HealthChecker/ApplyConfig without making real network
+// calls.
+type fakeChecker struct{}
+
+func (f *fakeChecker) Check(ctx context.Context) error { return nil }
+
+func init() {
+	RegisterChecker("fake", func(cfg CheckerConfig) (Checker, error) {
+		return &fakeChecker{}, nil
+	})
+}
+
+func fakeCheckerConfig(name string, threshold int) CheckerConfig {
+	return CheckerConfig{Name: name, Type: "fake", Threshold: threshold, Settings: json.RawMessage("{}")}
+}
+
+// newTestHealthChecker builds a HealthChecker with its initial checkers
+// already started, without going through Run (which would also start
+// the HTTP/socket servers and the SIGHUP watcher). The caller must call
+// the returned cancel func and wait on h.wg before the test ends.
+func newTestHealthChecker(checkers []CheckerConfig) (*HealthChecker, context.CancelFunc) {
+	h := NewHealthChecker(Config{IntervalSeconds: 1, TimeoutSeconds: 1, Checkers: checkers})
+	ctx, cancel := context.WithCancel(context.Background())
+	h.runCtx = ctx
+	h.wg = &sync.WaitGroup{}
+
+	h.mu.RLock()
+	initial := make(map[string]Checker, len(h.checkers))
+	for name, checker := range h.checkers {
+		initial[name] = checker
+	}
+	h.mu.RUnlock()
+	for name, checker := range initial {
+		h.startChecker(ctx, name, checker)
+	}
+
+	return h, cancel
+}
+
+func TestApplyConfigAddsRemovesRestartsAndLeavesUnchanged(t *testing.T) {
+	h, cancel := newTestHealthChecker([]CheckerConfig{
+		fakeCheckerConfig("a", 1),
+		fakeCheckerConfig("b", 1),
+	})
+	defer func() {
+		cancel()
+		h.wg.Wait()
+	}()
+	bMetrics := h.getMetricsEntry("b")
+
+	h.ApplyConfig(Config{
+		IntervalSeconds: 1,
+		TimeoutSeconds:  1,
+		Checkers: []CheckerConfig{
+			fakeCheckerConfig("a", 3), // changed
+			fakeCheckerConfig("b", 1), // unchanged
+			fakeCheckerConfig("c", 1), // added
+		},
+	})
+
+	if got := h.getCheckerConfig("a").Threshold; got != 3 {
+		t.Errorf("checker a: threshold = %d, want 3 (changed config should be applied)", got)
+	}
+	if h.getMetricsEntry("c") == nil {
+		t.Error("checker c: not started by reload")
+	}
+	if h.getMetricsEntry("b") != bMetrics {
+		t.Error("checker b: metrics entry replaced by reload, want unchanged checker left untouched")
+	}
+
+	h.ApplyConfig(Config{
+		IntervalSeconds: 1,
+		TimeoutSeconds:  1,
+		Checkers: []CheckerConfig{
+			fakeCheckerConfig("b", 1),
+			fakeCheckerConfig("c", 1),
+		},
+	})
+
+	if _, ok := h.registry.Get("a"); ok {
+		t.Error("checker a: registry snapshot not cleared after being removed by reload")
+	}
+	h.mu.RLock()
+	_, stillConfigured := h.checkerConfigs["a"]
+	_, stillHasMetrics := h.metrics["a"]
+	h.mu.RUnlock()
+	if stillConfigured {
+		t.Error("checker a: still in checkerConfigs after being removed by reload")
+	}
+	if stillHasMetrics {
+		t.Error("checker a: metrics entry not cleared after being removed by reload")
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// responseTimeBuckets are the upper bounds, in seconds, of the
+// cumulative histogram buckets exposed for each checker's response
+// time. These mirror the Prometheus client library's default buckets.
+var responseTimeBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// CheckerSnapshot is an immutable, point-in-time view of a single
+// checker's metrics. It is safe to read without holding any lock.
+type CheckerSnapshot struct {
+	Name               string
+	TotalChecks        int
+	SuccessfulChecks   int
+	ConsecutiveFails   int
+	ConsecutiveSuccess int
+	Healthy            bool
+	LastError          error
+	// LastUnhealthyError is the error that caused Healthy's most recent
+	// transition to false. It stays populated through the successful
+	// checks a SuccessThreshold > 1 requires before Healthy flips back
+	// to true, so it's always non-nil when Healthy is false.
+	LastUnhealthyError  error
+	LastCheckTime       time.Time
+	Threshold           int
+	SuccessThreshold    int
+	ResponseTimeSum     time.Duration
+	ResponseTimeBuckets []int
+}
+
+// Registry holds the latest CheckerSnapshot published for every
+// checker. The monitoring goroutines publish a new snapshot after each
+// check; the logger goroutine and the /metrics and /healthz handlers
+// read from the registry instead of the per-target URLMetrics.Mutex, so a slow
+// /metrics scrape never contends with an in-flight check.
+type Registry struct {
+	mu        sync.RWMutex
+	snapshots map[string]CheckerSnapshot
+}
+
+func NewRegistry() *Registry {
+	return &Registry{snapshots: make(map[string]CheckerSnapshot)}
+}
+
+// Set publishes snap as the current snapshot for name.
+func (r *Registry) Set(name string, snap CheckerSnapshot) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.snapshots[name] = snap
+}
+
+// Delete removes name's snapshot, if one has been published. It is a
+// no-op if name has no snapshot.
+func (r *Registry) Delete(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.snapshots, name)
+}
+
+// Get returns the current snapshot for name, if one has been published.
+func (r *Registry) Get(name string) (CheckerSnapshot, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	snap, ok := r.snapshots[name]
+	return snap, ok
+}
+
+// All returns a copy of every published snapshot, keyed by checker
+// name.
+func (r *Registry) All() map[string]CheckerSnapshot {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make(map[string]CheckerSnapshot, len(r.snapshots))
+	for name, snap := range r.snapshots {
+		out[name] = snap
+	}
+	return out
+}
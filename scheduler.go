@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// AsyncScheduler runs Task on a jittered interval, firing the first run
+// immediately instead of waiting a full interval. At most one
+// invocation of Task is ever in flight: if a tick fires while the
+// previous run hasn't finished, that tick is skipped and counted as an
+// overrun rather than piling up another goroutine.
+type AsyncScheduler struct {
+	Name           string
+	Interval       time.Duration
+	Timeout        time.Duration
+	JitterFraction float64
+	Task           func(ctx context.Context)
+
+	running  atomic.Bool
+	overruns atomic.Int64
+	taskWG   sync.WaitGroup
+}
+
+// Overruns returns the number of ticks skipped because the previous run
+// of Task was still in flight.
+func (s *AsyncScheduler) Overruns() int64 {
+	return s.overruns.Load()
+}
+
+// Run fires Task immediately and then on every jittered interval,
+// until ctx is done. It waits for any in-flight Task to finish before
+// calling wg.Done, so callers can wg.Add(1) before running Run in a
+// goroutine and wg.Wait() to synchronize on every scheduler's
+// termination deterministically.
+func (s *AsyncScheduler) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+	defer s.taskWG.Wait()
+
+	s.spawn(ctx)
+	for {
+		timer := time.NewTimer(s.nextInterval())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			s.spawn(ctx)
+		}
+	}
+}
+
+// spawn runs Task in its own goroutine with a context.WithTimeout
+// derived from ctx, unless a previous run is still in flight.
+func (s *AsyncScheduler) spawn(ctx context.Context) {
+	if !s.running.CompareAndSwap(false, true) {
+		n := s.overruns.Add(1)
+		slog.Warn("skipping check, previous run still in flight",
+			"checker", s.Name, "overruns", n)
+		return
+	}
+
+	s.taskWG.Add(1)
+	go func() {
+		defer s.taskWG.Done()
+		defer s.running.Store(false)
+
+		taskCtx, cancel := context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+		s.Task(taskCtx)
+	}()
+}
+
+// minInterval is the floor nextInterval clamps to, so a misconfigured
+// or zero Interval can't turn Run into an unthrottled busy loop.
+const minInterval = 100 * time.Millisecond
+
+// nextInterval applies up to ±JitterFraction of random jitter to
+// Interval, to avoid a thundering herd of checks against the same
+// target. A JitterFraction <= 0 disables jitter entirely. A non-positive
+// Interval yields minInterval, and a jittered result is never below
+// minInterval; a positive Interval with jitter disabled is returned as is.
+func (s *AsyncScheduler) nextInterval() time.Duration {
+	if s.Interval <= 0 {
+		return minInterval
+	}
+	if s.JitterFraction <= 0 {
+		return s.Interval
+	}
+	jitter := (rand.Float64()*2 - 1) * s.JitterFraction
+	next := time.Duration(float64(s.Interval) * (1 + jitter))
+	if next < minInterval {
+		return minInterval
+	}
+	return next
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAsyncSchedulerNextInterval(t *testing.T) {
+	cases := []struct {
+		name             string
+		interval         time.Duration
+		jitterFraction   float64
+		wantMin, wantMax time.Duration
+	}{
+		{"no jitter", 200 * time.Millisecond, 0, 200 * time.Millisecond, 200 * time.Millisecond},
+		{"jittered", 200 * time.Millisecond, 0.5, 100 * time.Millisecond, 300 * time.Millisecond},
+		{"zero interval clamps to floor", 0, 0, minInterval, minInterval},
+		{"negative interval clamps to floor", -time.Second, 0.5, minInterval, minInterval},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &AsyncScheduler{Interval: tc.interval, JitterFraction: tc.jitterFraction}
+			for i := 0; i < 20; i++ {
+				got := s.nextInterval()
+				if got < tc.wantMin || got > tc.wantMax {
+					t.Fatalf("nextInterval() = %v, want in [%v, %v]", got, tc.wantMin, tc.wantMax)
+				}
+			}
+		})
+	}
+}
+
+func TestAsyncSchedulerRunSkipsOverrunningTicks(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	s := &AsyncScheduler{
+		Name:     "test",
+		Interval: 5 * time.Millisecond,
+		Timeout:  time.Second,
+		Task: func(ctx context.Context) {
+			calls.Add(1)
+			<-release
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go s.Run(ctx, &wg)
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("calls = %d, want 1 (later ticks should be skipped while the first Task is still in flight)", calls.Load())
+	}
+	if s.Overruns() == 0 {
+		t.Error("Overruns() = 0, want > 0 (ticks should have been skipped)")
+	}
+}
+
+func TestAsyncSchedulerRunEnforcesTimeout(t *testing.T) {
+	done := make(chan error, 1)
+	s := &AsyncScheduler{
+		Name:     "test",
+		Interval: time.Hour,
+		Timeout:  10 * time.Millisecond,
+		Task: func(ctx context.Context) {
+			<-ctx.Done()
+			done <- ctx.Err()
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go s.Run(ctx, &wg)
+
+	select {
+	case err := <-done:
+		if err != context.DeadlineExceeded {
+			t.Errorf("task ctx.Err() = %v, want context.DeadlineExceeded", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("task context was never cancelled by Timeout")
+	}
+
+	cancel()
+	wg.Wait()
+}
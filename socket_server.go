@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+)
+
+// defaultSocketPath is where the status Unix socket listens when
+// Config.SocketPath isn't set, and what the `status` CLI subcommand
+// dials by default.
+const defaultSocketPath = "/tmp/health-checker.sock"
+
+// serveSocket starts a Unix-socket HTTP server exposing the structured
+// status snapshot at /status, for the `health-checker status` CLI
+// subcommand to consume. It blocks until ctx is done.
+func (h *HealthChecker) serveSocket(ctx context.Context) {
+	path := h.getConfig().SocketPath
+	if path == "" {
+		path = defaultSocketPath
+	}
+
+	_ = os.Remove(path)
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		slog.Error("failed to listen on status socket", "path", path, "error", err)
+		return
+	}
+	defer os.Remove(path)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/status", h.handleStatus)
+	srv := &http.Server{Handler: mux}
+
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+
+	slog.Info("starting status socket server", "path", path)
+	if err := srv.Serve(listener); err != nil && err != http.ErrServerClosed {
+		slog.Error("status socket server stopped unexpectedly", "error", err)
+	}
+}
+
+func (h *HealthChecker) handleStatus(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(h.Snapshot()); err != nil {
+		slog.Error("failed to encode /status response", "error", err)
+	}
+}
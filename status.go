@@ -0,0 +1,165 @@
+package main
+
+import (
+	"sort"
+	"time"
+)
+
+// sample is one (timestamp, duration, success) observation kept in a
+// target's rolling window.
+type sample struct {
+	Timestamp time.Time
+	Duration  time.Duration
+	Success   bool
+}
+
+// ringBuffer is a fixed-size circular buffer of samples. Add is O(1)
+// regardless of window size, so a check's bookkeeping cost stays
+// constant no matter how large Config.WindowSize is configured.
+type ringBuffer struct {
+	samples []sample
+	next    int
+	filled  bool
+}
+
+func newRingBuffer(size int) *ringBuffer {
+	if size <= 0 {
+		size = 1
+	}
+	return &ringBuffer{samples: make([]sample, size)}
+}
+
+// Add records s, overwriting the oldest sample once the buffer is full.
+func (r *ringBuffer) Add(s sample) {
+	r.samples[r.next] = s
+	r.next = (r.next + 1) % len(r.samples)
+	if r.next == 0 {
+		r.filled = true
+	}
+}
+
+// Samples returns a copy of the buffer's current samples, in no
+// particular order.
+func (r *ringBuffer) Samples() []sample {
+	if r.filled {
+		out := make([]sample, len(r.samples))
+		copy(out, r.samples)
+		return out
+	}
+	out := make([]sample, r.next)
+	copy(out, r.samples[:r.next])
+	return out
+}
+
+// StatusCoder is implemented by checkers that have a meaningful status
+// code to report, such as HTTPChecker. Snapshot uses it to populate
+// TargetSnapshot.LastStatusCode when available.
+type StatusCoder interface {
+	LastStatusCode() int
+}
+
+// TargetSnapshot is the structured, JSON-serializable status of one
+// checker.
+type TargetSnapshot struct {
+	Name                string    `json:"name"`
+	Healthy             bool      `json:"healthy"`
+	LastStatusCode      int       `json:"last_status_code,omitempty"`
+	LastError           string    `json:"last_error,omitempty"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	LastCheckTime       time.Time `json:"last_check_time"`
+	UptimePercent       float64   `json:"uptime_percent"`
+	P50ResponseTimeMS   int64     `json:"p50_response_time_ms"`
+	P95ResponseTimeMS   int64     `json:"p95_response_time_ms"`
+}
+
+// StatusSnapshot is the top-level shape returned by
+// HealthChecker.Snapshot, served as JSON at /status and consumed by the
+// `health-checker status` CLI subcommand.
+type StatusSnapshot struct {
+	Targets []TargetSnapshot `json:"targets"`
+}
+
+// Snapshot returns the current structured status of every checker that
+// has published at least one check result, sorted by name for stable
+// output. Checkers that have not completed a check yet are omitted.
+func (h *HealthChecker) Snapshot() StatusSnapshot {
+	h.mu.RLock()
+	names := make([]string, 0, len(h.checkers))
+	checkers := make(map[string]Checker, len(h.checkers))
+	for name, checker := range h.checkers {
+		names = append(names, name)
+		checkers[name] = checker
+	}
+	h.mu.RUnlock()
+	sort.Strings(names)
+
+	targets := make([]TargetSnapshot, 0, len(names))
+	for _, name := range names {
+		snap, ok := h.registry.Get(name)
+		if !ok {
+			continue
+		}
+
+		ts := TargetSnapshot{
+			Name:                name,
+			Healthy:             snap.Healthy,
+			ConsecutiveFailures: snap.ConsecutiveFails,
+			LastCheckTime:       snap.LastCheckTime,
+		}
+		if snap.LastError != nil {
+			ts.LastError = snap.LastError.Error()
+		}
+		if coder, ok := checkers[name].(StatusCoder); ok {
+			ts.LastStatusCode = coder.LastStatusCode()
+		}
+		ts.UptimePercent, ts.P50ResponseTimeMS, ts.P95ResponseTimeMS = summarizeWindow(h.windowSamples(name))
+
+		targets = append(targets, ts)
+	}
+
+	return StatusSnapshot{Targets: targets}
+}
+
+// windowSamples returns a copy of name's rolling-window samples.
+func (h *HealthChecker) windowSamples(name string) []sample {
+	m := h.getMetricsEntry(name)
+	if m == nil {
+		return nil
+	}
+	m.Mutex.RLock()
+	defer m.Mutex.RUnlock()
+	return m.Window.Samples()
+}
+
+// summarizeWindow computes the uptime percentage and p50/p95 response
+// times, in milliseconds, over samples.
+func summarizeWindow(samples []sample) (uptimePercent float64, p50ms, p95ms int64) {
+	if len(samples) == 0 {
+		return 0, 0, 0
+	}
+
+	successes := 0
+	durations := make([]time.Duration, len(samples))
+	for i, s := range samples {
+		durations[i] = s.Duration
+		if s.Success {
+			successes++
+		}
+	}
+	uptimePercent = 100 * float64(successes) / float64(len(samples))
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	p50ms = durations[percentileIndex(len(durations), 0.50)].Milliseconds()
+	p95ms = durations[percentileIndex(len(durations), 0.95)].Milliseconds()
+	return uptimePercent, p50ms, p95ms
+}
+
+// percentileIndex maps percentile p (0-1) over n samples to an index
+// into a slice sorted ascending.
+func percentileIndex(n int, p float64) int {
+	idx := int(float64(n) * p)
+	if idx >= n {
+		idx = n - 1
+	}
+	return idx
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+// runStatusCommand implements the `health-checker status` CLI
+// subcommand: it dials a running instance's Unix socket, fetches the
+// structured status snapshot, and pretty-prints it as a table.
+func runStatusCommand(socketPath string) {
+	if socketPath == "" {
+		socketPath = defaultSocketPath
+	}
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return net.Dial("unix", socketPath)
+			},
+		},
+	}
+
+	resp, err := client.Get("http://unix/status")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "connecting to %s: %v\n", socketPath, err)
+		os.Exit(1)
+	}
+	defer resp.Body.Close()
+
+	var snap StatusSnapshot
+	if err := json.NewDecoder(resp.Body).Decode(&snap); err != nil {
+		fmt.Fprintf(os.Stderr, "decoding status response: %v\n", err)
+		os.Exit(1)
+	}
+
+	printStatusTable(os.Stdout, snap)
+}
+
+func printStatusTable(w io.Writer, snap StatusSnapshot) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tHEALTHY\tSTATUS\tFAILS\tUPTIME%\tP50\tP95\tLAST CHECK")
+	for _, t := range snap.Targets {
+		fmt.Fprintf(tw, "%s\t%t\t%d\t%d\t%.1f\t%dms\t%dms\t%s\n",
+			t.Name, t.Healthy, t.LastStatusCode, t.ConsecutiveFailures,
+			t.UptimePercent, t.P50ResponseTimeMS, t.P95ResponseTimeMS,
+			t.LastCheckTime.Format(time.Kitchen))
+	}
+	tw.Flush()
+}
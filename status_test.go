@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRingBufferAddAndSamples(t *testing.T) {
+	r := newRingBuffer(3)
+	if got := len(r.Samples()); got != 0 {
+		t.Fatalf("Samples() len = %d, want 0 for an empty buffer", got)
+	}
+
+	base := time.Unix(0, 0)
+	for i := 0; i < 5; i++ {
+		r.Add(sample{
+			Timestamp: base.Add(time.Duration(i) * time.Second),
+			Duration:  time.Duration(i) * time.Millisecond,
+			Success:   i%2 == 0,
+		})
+	}
+
+	samples := r.Samples()
+	if len(samples) != 3 {
+		t.Fatalf("Samples() len = %d, want 3 (buffer size) once it has wrapped", len(samples))
+	}
+	want := map[time.Duration]bool{2 * time.Millisecond: true, 3 * time.Millisecond: true, 4 * time.Millisecond: true}
+	for _, s := range samples {
+		if !want[s.Duration] {
+			t.Errorf("unexpected sample duration %v in buffer, oldest samples should have been overwritten", s.Duration)
+		}
+	}
+}
+
+func TestSummarizeWindow(t *testing.T) {
+	samples := []sample{
+		{Duration: 10 * time.Millisecond, Success: true},
+		{Duration: 20 * time.Millisecond, Success: true},
+		{Duration: 30 * time.Millisecond, Success: false},
+		{Duration: 40 * time.Millisecond, Success: true},
+	}
+
+	uptime, p50, p95 := summarizeWindow(samples)
+	if uptime != 75 {
+		t.Errorf("uptime = %v, want 75", uptime)
+	}
+	if p50 != 30 {
+		t.Errorf("p50 = %dms, want 30ms", p50)
+	}
+	if p95 != 40 {
+		t.Errorf("p95 = %dms, want 40ms", p95)
+	}
+}
+
+func TestSummarizeWindowEmpty(t *testing.T) {
+	uptime, p50, p95 := summarizeWindow(nil)
+	if uptime != 0 || p50 != 0 || p95 != 0 {
+		t.Errorf("summarizeWindow(nil) = (%v, %d, %d), want all zero", uptime, p50, p95)
+	}
+}